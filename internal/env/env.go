@@ -7,6 +7,29 @@ import (
 	"runtime"
 )
 
+// logger is resolved lazily to avoid an import cycle: internal/logging depends
+// on this package for GetLogsDir().
+var logger debugLogger
+
+// debugLogger is the minimal surface env needs from *slog.Logger, satisfied by
+// internal/logging.Logger() via SetDebugLogger.
+type debugLogger interface {
+	Debug(msg string, args ...any)
+}
+
+// SetDebugLogger wires a structured logger for env's own diagnostics (instance
+// detection). Call it once at startup with internal/logging.Logger(); until
+// then, debug messages are simply dropped.
+func SetDebugLogger(l debugLogger) {
+	logger = l
+}
+
+func logDebug(msg string, args ...any) {
+	if logger != nil {
+		logger.Debug(msg, args...)
+	}
+}
+
 // IsFlatpak returns true if running inside a Flatpak sandbox
 func IsFlatpak() bool {
 	// Flatpak sets FLATPAK_ID environment variable
@@ -201,37 +224,35 @@ func ListInstances() ([]string, error) {
 func IsVersionInstalled(branch string, version int) bool {
 	instanceDir := GetInstanceDir(branch, version)
 	gameDir := GetInstanceGameDir(branch, version)
-	
-	fmt.Printf("[DEBUG] IsVersionInstalled: Checking %s v%d\n", branch, version)
-	fmt.Printf("[DEBUG] Instance dir: %s\n", instanceDir)
-	fmt.Printf("[DEBUG] Game dir: %s\n", gameDir)
-	
+
+	logDebug("IsVersionInstalled: checking", "branch", branch, "version", version, "instanceDir", instanceDir, "gameDir", gameDir)
+
 	// First check if instance directory exists
 	if _, err := os.Stat(instanceDir); os.IsNotExist(err) {
-		fmt.Printf("[DEBUG] Instance directory does not exist\n")
+		logDebug("IsVersionInstalled: instance directory does not exist", "instanceDir", instanceDir)
 		return false
 	}
-	
+
 	// Check if game directory exists
 	if _, err := os.Stat(gameDir); os.IsNotExist(err) {
-		fmt.Printf("[DEBUG] Game directory does not exist\n")
+		logDebug("IsVersionInstalled: game directory does not exist", "gameDir", gameDir)
 		return false
 	}
-	
+
 	// Check if Client folder exists with content (simplest check that works)
 	clientDir := filepath.Join(gameDir, "Client")
 	if entries, err := os.ReadDir(clientDir); err == nil && len(entries) > 0 {
-		fmt.Printf("[DEBUG] Client folder found with %d entries - game is installed\n", len(entries))
+		logDebug("IsVersionInstalled: Client folder found, game is installed", "entries", len(entries))
 		return true
 	}
-	
+
 	// If no Client folder, check if game directory has content (at least a few files/folders)
 	if entries, err := os.ReadDir(gameDir); err == nil && len(entries) >= 2 {
-		fmt.Printf("[DEBUG] Game dir has %d entries - considering installed\n", len(entries))
+		logDebug("IsVersionInstalled: game dir has content, considering installed", "entries", len(entries))
 		return true
 	}
-	
-	fmt.Printf("[DEBUG] No valid game installation found\n")
+
+	logDebug("IsVersionInstalled: no valid game installation found")
 	return false
 }
 
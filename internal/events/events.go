@@ -0,0 +1,88 @@
+// Package events is a small typed publish/subscribe bus that lets UI code
+// observe download and update activity without the download/updater packages
+// depending on any particular UI framework.
+package events
+
+import "sync"
+
+// DownloadStarted is published when a download begins.
+type DownloadStarted struct {
+	URL  string
+	Dest string
+	Size int64
+}
+
+// DownloadProgress is published periodically while a download is in flight, at
+// the same cadence as the UI progress callback.
+type DownloadProgress struct {
+	URL        string
+	Downloaded int64
+	Total      int64
+	SpeedBps   float64
+}
+
+// DownloadRetry is published when a failed download attempt is about to be retried.
+type DownloadRetry struct {
+	URL     string
+	Attempt int
+	Err     error
+}
+
+// DownloadFailed is published when a download exhausts all retries.
+type DownloadFailed struct {
+	URL string
+	Err error
+}
+
+// UpdateAvailable is published when CheckUpdate finds a newer launcher version.
+type UpdateAvailable struct {
+	CurrentVersion string
+	LatestVersion  string
+}
+
+var (
+	mu          sync.RWMutex
+	subscribers []chan any
+)
+
+// Subscribe registers a new listener and returns a channel that receives every
+// event published after this call. The channel is buffered; a subscriber that
+// falls behind drops events rather than blocking publishers. Call Unsubscribe
+// when the listener is done.
+func Subscribe() <-chan any {
+	ch := make(chan any, 32)
+
+	mu.Lock()
+	subscribers = append(subscribers, ch)
+	mu.Unlock()
+
+	return ch
+}
+
+// Unsubscribe removes a channel previously returned by Subscribe and closes it.
+func Unsubscribe(ch <-chan any) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for i, sub := range subscribers {
+		if sub == ch {
+			subscribers = append(subscribers[:i], subscribers[i+1:]...)
+			close(sub)
+			return
+		}
+	}
+}
+
+// Publish sends event to every current subscriber, dropping it for subscribers
+// whose buffer is full instead of blocking the caller.
+func Publish(event any) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for _, sub := range subscribers {
+		select {
+		case sub <- event:
+		default:
+		}
+	}
+}
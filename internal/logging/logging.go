@@ -0,0 +1,66 @@
+// Package logging provides the process-wide structured logger. It writes JSON
+// records to GetLogsDir()/hyprism.log so GUI/Flatpak builds, which have no
+// usable stdout, still produce a log file users can attach to bug reports.
+package logging
+
+import (
+	"HyPrism/internal/env"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	logFileName  = "hyprism.log"
+	maxLogSize   = 5 * 1024 * 1024 // rotate at startup if the existing log has reached this size
+	maxRotations = 3
+)
+
+var (
+	once   sync.Once
+	logger *slog.Logger
+)
+
+// Logger returns the process-wide structured logger, opening (and rotating, if
+// needed) GetLogsDir()/hyprism.log on first use.
+func Logger() *slog.Logger {
+	once.Do(func() {
+		logger = newLogger()
+	})
+	return logger
+}
+
+func newLogger() *slog.Logger {
+	path := filepath.Join(env.GetLogsDir(), logFileName)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return slog.New(slog.NewJSONHandler(os.Stderr, nil))
+	}
+
+	rotate(path)
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return slog.New(slog.NewJSONHandler(os.Stderr, nil))
+	}
+
+	return slog.New(slog.NewJSONHandler(file, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+// rotate shifts path.1..path.(maxRotations-1) along by one and moves path itself
+// to path.1 when it has reached maxLogSize. It runs only when the logger is
+// first opened; the active log is not rotated while the process is running.
+func rotate(path string) {
+	stat, err := os.Stat(path)
+	if err != nil || stat.Size() < maxLogSize {
+		return
+	}
+
+	os.Remove(fmt.Sprintf("%s.%d", path, maxRotations))
+	for i := maxRotations - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", path, i), fmt.Sprintf("%s.%d", path, i+1))
+	}
+	os.Rename(path, path+".1")
+}
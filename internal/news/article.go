@@ -0,0 +1,116 @@
+package news
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Article is the full content of a single news post, beyond what NewsItem's
+// BodyExcerpt carries for the listing view.
+type Article struct {
+	Title         string
+	Author        string
+	Tags          []string
+	Body          string // raw markdown from the API; the <article> element's inner HTML when scraped from the page
+	CoverImageURL string
+	PublishedAt   string
+	Slug          string
+	URL           string
+}
+
+// FetchArticle fetches a single post's full body from the Hytale blog's
+// single-post API, falling back to scraping the public news page built from
+// slug when the API request fails for any reason, including the API not
+// having the post (e.g. an older or unlisted post).
+func FetchArticle(ctx context.Context, slug string) (Article, error) {
+	article, err := fetchArticleAPI(ctx, slug)
+	if err == nil {
+		return article, nil
+	}
+
+	return fetchArticlePage(ctx, slug)
+}
+
+func fetchArticleAPI(ctx context.Context, slug string) (Article, error) {
+	client := &http.Client{Timeout: 15 * time.Second}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("https://hytale.com/api/blog/post/%s", slug), nil)
+	if err != nil {
+		return Article{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "HyPrism/1.0")
+	req.Header.Set("Accept", "application/json")
+
+	body, err := fetchCached(client, req)
+	if err != nil {
+		return Article{}, fmt.Errorf("failed to fetch article %s: %w", slug, err)
+	}
+
+	var post struct {
+		Title       string     `json:"title"`
+		Author      string     `json:"author"`
+		Tags        []string   `json:"tags"`
+		Body        string     `json:"body"`
+		PublishedAt string     `json:"publishedAt"`
+		CoverImage  coverImage `json:"coverImage"`
+	}
+	if err := json.Unmarshal(body, &post); err != nil {
+		return Article{}, fmt.Errorf("failed to parse article %s: %w", slug, err)
+	}
+	if post.Title == "" {
+		return Article{}, fmt.Errorf("article %s not found", slug)
+	}
+
+	articleURL, err := parseUrl(post.PublishedAt, slug)
+	if err != nil {
+		articleURL = fmt.Sprintf("https://hytale.com/news/%s", slug)
+	}
+
+	return Article{
+		Title:         post.Title,
+		Author:        post.Author,
+		Tags:          post.Tags,
+		Body:          post.Body,
+		CoverImageURL: CDN_URL + post.CoverImage.S3Key,
+		PublishedAt:   post.PublishedAt,
+		Slug:          slug,
+		URL:           articleURL,
+	}, nil
+}
+
+// articleBodyPattern extracts the <article> element's contents from the
+// public news page HTML, used when the JSON API doesn't have the post.
+var articleBodyPattern = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+
+func fetchArticlePage(ctx context.Context, slug string) (Article, error) {
+	pageURL := fmt.Sprintf("https://hytale.com/news/%s", slug)
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return Article{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "HyPrism/1.0")
+
+	body, err := fetchCached(client, req)
+	if err != nil {
+		return Article{}, fmt.Errorf("failed to fetch %s: %w", pageURL, err)
+	}
+
+	match := articleBodyPattern.FindSubmatch(body)
+	if match == nil {
+		return Article{}, fmt.Errorf("could not find article body at %s", pageURL)
+	}
+
+	return Article{
+		Body: html.UnescapeString(strings.TrimSpace(string(match[1]))),
+		Slug: slug,
+		URL:  pageURL,
+	}, nil
+}
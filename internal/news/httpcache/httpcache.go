@@ -0,0 +1,240 @@
+// Package httpcache implements a persistent, on-disk cache for small HTTP
+// responses (news feeds, API listings), honoring ETag/Last-Modified for
+// conditional revalidation and Cache-Control/Expires for expiry. Unlike
+// download/cache, which content-addresses large binary assets on the
+// filesystem, entries here are small enough to store inline alongside their
+// validators so cached feed responses survive process restarts.
+package httpcache
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxBodyBytes bounds how much of a response body Fetch will buffer, so a
+// misbehaving or compromised feed can't exhaust memory or disk.
+const MaxBodyBytes = 5 * 1024 * 1024
+
+// defaultTTL is used when a response carries neither Cache-Control nor Expires.
+const defaultTTL = 10 * time.Minute
+
+// Entry is a cached response plus the validators and expiry needed to
+// revalidate or reuse it.
+type Entry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	Body         []byte    `json:"body"`
+	StoredAt     time.Time `json:"storedAt"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+}
+
+func (e Entry) fresh() bool {
+	return time.Now().Before(e.ExpiresAt)
+}
+
+// Cache is a small on-disk JSON store of Entry keyed by URL.
+type Cache struct {
+	path    string
+	mu      sync.Mutex
+	entries map[string]Entry
+}
+
+// Open loads (or creates) the cache file at <dir>/httpcache.json.
+func Open(dir string) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	c := &Cache{
+		path:    filepath.Join(dir, "httpcache.json"),
+		entries: map[string]Entry{},
+	}
+
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &c.entries); err != nil {
+		return nil, fmt.Errorf("failed to parse http cache: %w", err)
+	}
+
+	return c, nil
+}
+
+// Fetch returns req's response body, serving a cached copy directly while it
+// is still fresh, conditionally revalidating (If-None-Match /
+// If-Modified-Since) once it has expired, and falling back to the last
+// cached copy if the request fails or the server errors. req must not
+// already carry a body.
+func (c *Cache) Fetch(client *http.Client, req *http.Request) ([]byte, error) {
+	url := req.URL.String()
+
+	c.mu.Lock()
+	prev, hasPrev := c.entries[url]
+	c.mu.Unlock()
+
+	if hasPrev && prev.fresh() {
+		return prev.Body, nil
+	}
+
+	if hasPrev {
+		if prev.ETag != "" {
+			req.Header.Set("If-None-Match", prev.ETag)
+		}
+		if prev.LastModified != "" {
+			req.Header.Set("If-Modified-Since", prev.LastModified)
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if hasPrev {
+			return prev.Body, nil
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if hasPrev && resp.StatusCode == http.StatusNotModified {
+		prev.ExpiresAt = expiryFromHeaders(resp.Header)
+		prev.StoredAt = time.Now()
+		return prev.Body, c.put(prev)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if hasPrev {
+			return prev.Body, nil
+		}
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	body, err := ReadLimited(resp)
+	if err != nil {
+		if hasPrev {
+			return prev.Body, nil
+		}
+		return nil, err
+	}
+
+	entry := Entry{
+		URL:          url,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Body:         body,
+		StoredAt:     time.Now(),
+		ExpiresAt:    expiryFromHeaders(resp.Header),
+	}
+
+	return body, c.put(entry)
+}
+
+// ReadLimited reads resp.Body up to MaxBodyBytes, returning an error if the
+// response exceeds that bound instead of silently truncating it.
+func ReadLimited(resp *http.Response) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxBodyBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if len(body) > MaxBodyBytes {
+		return nil, fmt.Errorf("response exceeds %d bytes", MaxBodyBytes)
+	}
+	return body, nil
+}
+
+// Get returns the body previously stored under key by Set, if it is still
+// fresh. Unlike Fetch, key is an arbitrary cache key rather than a request
+// URL, for callers (e.g. rendered article output) caching a computed result
+// rather than a raw HTTP response.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+
+	if !ok || !entry.fresh() {
+		return nil, false
+	}
+	return entry.Body, true
+}
+
+// Set stores body under key with the given TTL, independent of HTTP
+// validators.
+func (c *Cache) Set(key string, body []byte, ttl time.Duration) error {
+	return c.put(Entry{
+		URL:       key,
+		Body:      body,
+		StoredAt:  time.Now(),
+		ExpiresAt: time.Now().Add(ttl),
+	})
+}
+
+func (c *Cache) put(entry Entry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[entry.URL] = entry
+	return c.save()
+}
+
+func (c *Cache) save() error {
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
+
+// expiryFromHeaders derives a cache expiry from Cache-Control's s-maxage or
+// max-age directives, falling back to Expires, and finally defaultTTL when
+// the response specifies neither.
+func expiryFromHeaders(h http.Header) time.Time {
+	now := time.Now()
+
+	if cc := h.Get("Cache-Control"); cc != "" {
+		if ttl, ok := maxAgeFrom(cc, "s-maxage"); ok {
+			return now.Add(ttl)
+		}
+		if ttl, ok := maxAgeFrom(cc, "max-age"); ok {
+			return now.Add(ttl)
+		}
+	}
+
+	if exp := h.Get("Expires"); exp != "" {
+		if t, err := http.ParseTime(exp); err == nil {
+			return t
+		}
+	}
+
+	return now.Add(defaultTTL)
+}
+
+func maxAgeFrom(cacheControl, directive string) (time.Duration, bool) {
+	for _, part := range strings.Split(cacheControl, ",") {
+		part = strings.TrimSpace(part)
+		if !strings.HasPrefix(part, directive+"=") {
+			continue
+		}
+		secs, err := strconv.Atoi(strings.TrimPrefix(part, directive+"="))
+		if err != nil {
+			continue
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	return 0, false
+}
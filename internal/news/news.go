@@ -1,12 +1,17 @@
 package news
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"html"
-	"io"
 	"net/http"
+	"sort"
+	"strings"
+	"sync"
 	"time"
+
+	"HyPrism/internal/logging"
 )
 
 const CDN_URL = "https://cdn.hytale.com/variants/blog_thumb_"
@@ -26,67 +31,120 @@ type NewsItem struct {
 	CoverImage  coverImage `json:"coverImage"`
 	Author      string     `json:"author"`
 	ImageURL    string     `json:"imageUrl"`
+	Source      string     `json:"source"`
 }
 
-// FetchNews fetches news from hytale.com blog api
+// FetchNews fetches news from hytale.com blog api. It is a thin wrapper
+// around FetchNewsContext using context.Background() and non-strict schema
+// validation, kept for callers that don't need cancellation or FetchStats.
 func FetchNews(limit int) ([]NewsItem, error) {
-	client := &http.Client{
-		Timeout: 15 * time.Second,
-	}
+	items, _, err := FetchNewsContext(context.Background(), limit, false)
+	return items, err
+}
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("https://hytale.com/api/blog/post/published?limit=%d", limit), nil)
+// FetchNewsContext fetches news from hytale.com blog api, honoring ctx
+// cancellation and deadlines on the underlying request. When strict is
+// false, individual items that fail schema validation are skipped and
+// counted in the returned FetchStats instead of failing the whole fetch;
+// when strict is true, the first invalid item fails the whole call.
+func FetchNewsContext(ctx context.Context, limit int, strict bool) ([]NewsItem, FetchStats, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("https://hytale.com/api/blog/post/published?limit=%d", limit), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, FetchStats{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("User-Agent", "HyPrism/1.0")
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch news: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
+	body, err := fetchCached(sharedHTTPClient, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, FetchStats{}, fmt.Errorf("failed to fetch news: %w", err)
 	}
 
-	items, err := parseNewsJSON(string(body))
-	if err != nil {
-		return nil, err
-	}
+	return parseNewsJSON(string(body), strict)
+}
 
-	return items, nil
+// FetchStats reports how parseNewsJSON handled a batch of items, so callers
+// can distinguish "no news" from "the API's schema changed under us" instead
+// of silently showing a blank news panel.
+type FetchStats struct {
+	Parsed  int
+	Skipped int
+	Reasons []string
 }
 
-func parseNewsJSON(body string) ([]NewsItem, error) {
+// parseNewsJSON parses the Hytale blog API's response. In strict mode, any
+// item failing schema validation fails the whole batch; otherwise invalid
+// items are skipped individually and recorded in the returned FetchStats.
+func parseNewsJSON(body string, strict bool) ([]NewsItem, FetchStats, error) {
 	var items []NewsItem
-	json.Unmarshal([]byte(body), &items)
+	if err := json.Unmarshal([]byte(body), &items); err != nil {
+		return nil, FetchStats{}, fmt.Errorf("failed to parse news response: %w", err)
+	}
+
+	var stats FetchStats
+	valid := make([]NewsItem, 0, len(items))
 
 	for idx := range items {
-		var err error
+		if reason := invalidNewsItemReason(items[idx]); reason != "" {
+			if strict {
+				return nil, FetchStats{}, fmt.Errorf("invalid news item: %s", reason)
+			}
+			stats.Skipped++
+			stats.Reasons = append(stats.Reasons, reason)
+			logging.Logger().Warn("skipping malformed news item", "reason", reason)
+			continue
+		}
+
 		parsedUrl, err := parseUrl(items[idx].PublishedAt, items[idx].Slug)
 		if err != nil {
-			return nil, err
+			if strict {
+				return nil, FetchStats{}, err
+			}
+			stats.Skipped++
+			stats.Reasons = append(stats.Reasons, err.Error())
+			logging.Logger().Warn("skipping malformed news item", "reason", err.Error())
+			continue
 		}
 		parsedDate, err := parseDate(items[idx].PublishedAt)
 		if err != nil {
-			return nil, err
+			if strict {
+				return nil, FetchStats{}, err
+			}
+			stats.Skipped++
+			stats.Reasons = append(stats.Reasons, err.Error())
+			logging.Logger().Warn("skipping malformed news item", "reason", err.Error())
+			continue
 		}
+
 		items[idx].Date = parsedDate
 		items[idx].URL = parsedUrl
 		items[idx].Excerpt = html.UnescapeString(items[idx].BodyExcerpt)
 		items[idx].ImageURL = CDN_URL + items[idx].CoverImage.S3Key
 
+		valid = append(valid, items[idx])
 	}
 
-	return items, nil
+	stats.Parsed = len(valid)
+	return valid, stats, nil
+}
+
+// invalidNewsItemReason checks the fields WithStrictSchema requires, returning
+// a human-readable reason when item fails validation, or "" when it passes.
+func invalidNewsItemReason(item NewsItem) string {
+	if item.Title == "" {
+		return "missing title"
+	}
+	if item.Slug == "" {
+		return "missing slug"
+	}
+	if _, err := time.Parse(time.RFC3339, item.PublishedAt); err != nil {
+		return fmt.Sprintf("publishedAt %q is not RFC3339", item.PublishedAt)
+	}
+	if item.CoverImage.S3Key == "" {
+		return "missing cover image s3Key"
+	}
+	return ""
 }
 func parseUrl(publishedDate, slug string) (string, error) {
 	parsedDate, err := time.Parse(time.RFC3339, publishedDate)
@@ -120,43 +178,293 @@ func addOrdinal(n int) string {
 	}
 }
 
-// NewsService provides news fetching capabilities
+// NewsService aggregates news from multiple Sources, merging and caching the
+// result so callers see a single combined, de-duplicated feed.
 type NewsService struct {
+	sources []Source
+
+	// cacheMu guards cache/cacheTime, which Watch's background goroutine
+	// writes concurrently with foreground GetNews/GetNewsContext reads.
+	cacheMu   sync.Mutex
 	cache     []NewsItem
 	cacheTime time.Time
 	cacheTTL  time.Duration
+
+	// timeout and deadline bound how long GetNewsContext will wait on a
+	// caller's context that doesn't already carry its own deadline. Set via
+	// WithTimeout / WithDeadline; at most one applies at a time.
+	timeout  time.Duration
+	deadline time.Time
+
+	// strictSchema is shared with blogSource via a pointer so toggling it
+	// after construction (WithStrictSchema) affects the next fetch.
+	strictSchema bool
 }
 
-// NewNewsService creates a new news service
+// NewNewsService creates a news service wired to the Hytale blog and the
+// Hytale subreddit, each with its own cache TTL so a slow or rate-limited
+// source doesn't force the others to refetch. A community Discord mirror or
+// an official RSS/Atom feed can be added via WithDiscordMirror / WithRSSFeed
+// once a real, resolvable URL for one exists; neither is wired in by
+// default. TODO(fosterushka/HyPrism#chunk1-1): wire one in as soon as a real
+// mirror/feed URL is known.
 func NewNewsService() *NewsService {
-	return &NewsService{
+	s := &NewsService{
 		cacheTTL: 5 * time.Minute,
 	}
+	s.sources = []Source{
+		withCache(&blogSource{strict: &s.strictSchema}, 5*time.Minute),
+		withCache(redditSource{subreddit: "Hytale"}, 10*time.Minute),
+	}
+	return s
+}
+
+// WithDiscordMirror adds a community-maintained JSON mirror of a Discord
+// announcements channel as a news source, cached with the given ttl. It
+// returns s so it can be chained off NewNewsService. Pass the mirror's real
+// URL; there is no default because no resolvable mirror is wired in yet.
+func (s *NewsService) WithDiscordMirror(name, url string, ttl time.Duration) *NewsService {
+	s.sources = append(s.sources, withCache(discordMirrorSource{name: name, url: url}, ttl))
+	return s
+}
+
+// WithRSSFeed adds a generic RSS 2.0 or Atom feed as a news source, cached
+// with the given ttl. It returns s so it can be chained off NewNewsService.
+// Pass the feed's real URL; there is no default because no resolvable feed
+// is wired in yet.
+func (s *NewsService) WithRSSFeed(name, url string, ttl time.Duration) *NewsService {
+	s.sources = append(s.sources, withCache(rssSource{name: name, url: url}, ttl))
+	return s
+}
+
+// WithStrictSchema toggles strict schema validation for the Hytale blog
+// source: when true, a malformed item fails the whole fetch instead of being
+// skipped and counted in FetchStats. It returns s so it can be chained off
+// NewNewsService.
+func (s *NewsService) WithStrictSchema(strict bool) *NewsService {
+	s.strictSchema = strict
+	return s
+}
+
+// WithTimeout sets a default per-call timeout that GetNewsContext applies
+// whenever the caller's context doesn't already carry a deadline. It returns
+// s so it can be chained off NewNewsService.
+func (s *NewsService) WithTimeout(d time.Duration) *NewsService {
+	s.timeout = d
+	s.deadline = time.Time{}
+	return s
 }
 
-// GetNews returns cached news or fetches new news if cache is expired
+// WithDeadline is the WithTimeout equivalent for a fixed point in time.
+func (s *NewsService) WithDeadline(t time.Time) *NewsService {
+	s.deadline = t
+	s.timeout = 0
+	return s
+}
+
+// GetNews returns cached news or fetches a fresh merged feed if the cache is
+// expired. It is a thin wrapper around GetNewsContext using
+// context.Background().
 func (s *NewsService) GetNews(limit int) ([]NewsItem, error) {
-	// Check cache
-	if time.Since(s.cacheTime) < s.cacheTTL && len(s.cache) > 0 {
-		if len(s.cache) > limit {
-			return s.cache[:limit], nil
+	return s.GetNewsContext(context.Background(), limit)
+}
+
+// GetNewsContext returns cached news or fetches a fresh merged feed if the
+// cache is expired, honoring ctx cancellation: a context that is already
+// done, or that's cancelled while fetching, returns the last cached slice
+// immediately if one exists, or ctx.Err() otherwise.
+func (s *NewsService) GetNewsContext(ctx context.Context, limit int) ([]NewsItem, error) {
+	ctx, cancel := s.boundContext(ctx)
+	defer cancel()
+
+	select {
+	case <-ctx.Done():
+		if cached, ok := s.cacheSnapshot(); ok {
+			return truncate(cached, limit), nil
 		}
-		return s.cache, nil
+		return nil, ctx.Err()
+	default:
+	}
+
+	// Check cache
+	if cached, fresh := s.freshCacheSnapshot(); fresh {
+		return truncate(cached, limit), nil
 	}
 
-	// Fetch fresh news
-	items, err := FetchNews(limit)
+	// Fetch fresh news from every source
+	items, err := s.fetchAll(ctx, limit, false)
 	if err != nil {
-		// Return cached data if available
-		if len(s.cache) > 0 {
-			return s.cache, nil
+		// A cancelled/expired context or a cached fallback both prefer the
+		// last good slice over surfacing the error.
+		if cached, ok := s.cacheSnapshot(); ok {
+			return truncate(cached, limit), nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
 		}
 		return nil, err
 	}
 
 	// Update cache
+	s.cacheMu.Lock()
 	s.cache = items
 	s.cacheTime = time.Now()
+	s.cacheMu.Unlock()
+
+	return truncate(items, limit), nil
+}
+
+// RefreshNewsContext forces a fresh merged feed, bypassing both the
+// aggregate cache and any per-source cache (see freshFetcher), and updates
+// the aggregate cache with the result. Watch uses this so its interval is not
+// floored by a source's own cache TTL. The shared on-disk HTTP cache (see
+// fetchCached) is not bypassed: a response still within its Cache-Control /
+// Expires lifetime (10 minutes by default) is reused without a request. A
+// cancelled/expired context or a fetch failure falls back to the last cached
+// slice, the same as GetNewsContext.
+func (s *NewsService) RefreshNewsContext(ctx context.Context, limit int) ([]NewsItem, error) {
+	ctx, cancel := s.boundContext(ctx)
+	defer cancel()
+
+	items, err := s.fetchAll(ctx, limit, true)
+	if err != nil {
+		if cached, ok := s.cacheSnapshot(); ok {
+			return truncate(cached, limit), nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+
+	s.cacheMu.Lock()
+	s.cache = items
+	s.cacheTime = time.Now()
+	s.cacheMu.Unlock()
+
+	return truncate(items, limit), nil
+}
+
+// cacheSnapshot returns the cached items regardless of TTL freshness, and
+// whether there was anything cached at all.
+func (s *NewsService) cacheSnapshot() ([]NewsItem, bool) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	return s.cache, len(s.cache) > 0
+}
+
+// freshCacheSnapshot returns the cached items only while they're still
+// within cacheTTL. Both this and cacheSnapshot guard against concurrent
+// writers such as Watch's background refresh goroutine.
+func (s *NewsService) freshCacheSnapshot() ([]NewsItem, bool) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	if time.Since(s.cacheTime) < s.cacheTTL && len(s.cache) > 0 {
+		return s.cache, true
+	}
+	return nil, false
+}
+
+// boundContext derives a context that also respects s.timeout/s.deadline
+// when ctx doesn't already carry its own deadline.
+func (s *NewsService) boundContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	if !s.deadline.IsZero() {
+		return context.WithDeadline(ctx, s.deadline)
+	}
+	if s.timeout > 0 {
+		return context.WithTimeout(ctx, s.timeout)
+	}
+	return ctx, func() {}
+}
+
+// truncate returns items capped at limit. A negative limit is treated as "no
+// items" rather than indexing with a negative bound.
+func truncate(items []NewsItem, limit int) []NewsItem {
+	if limit < 0 {
+		return items[:0]
+	}
+	if len(items) > limit {
+		return items[:limit]
+	}
+	return items
+}
+
+// fetchAll fans out to every registered source in parallel, tags each item
+// with its originating source, merges the results, de-duplicates by URL and
+// title, and sorts by PublishedAt descending. A source that errors is
+// skipped rather than failing the whole fetch; all sources failing is the
+// only case that surfaces an error. When fresh is true, a source that wraps
+// its own cache (cachedSource) is asked to bypass it via freshFetcher instead
+// of possibly serving a result older than its own TTL.
+func (s *NewsService) fetchAll(ctx context.Context, limit int, fresh bool) ([]NewsItem, error) {
+	type sourceResult struct {
+		name  string
+		items []NewsItem
+		err   error
+	}
+
+	results := make([]sourceResult, len(s.sources))
+
+	var wg sync.WaitGroup
+	for i, src := range s.sources {
+		wg.Add(1)
+		go func(i int, src Source) {
+			defer wg.Done()
+			var items []NewsItem
+			var err error
+			if ff, ok := src.(freshFetcher); fresh && ok {
+				items, err = ff.FetchFresh(ctx, limit)
+			} else {
+				items, err = src.Fetch(ctx, limit)
+			}
+			results[i] = sourceResult{name: src.Name(), items: items, err: err}
+		}(i, src)
+	}
+	wg.Wait()
 
-	return items, nil
+	var merged []NewsItem
+	var failures []string
+	for _, res := range results {
+		if res.err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", res.name, res.err))
+			continue
+		}
+		// res.items may be a cachedSource's cache slice shared with other
+		// concurrent GetNewsContext callers; copy each item out before tagging
+		// it instead of mutating that shared backing array in place.
+		for _, item := range res.items {
+			item.Source = res.name
+			merged = append(merged, item)
+		}
+	}
+
+	if len(failures) > 0 && len(failures) == len(s.sources) {
+		return nil, fmt.Errorf("all news sources failed: %s", strings.Join(failures, "; "))
+	}
+
+	merged = dedupeNewsItems(merged)
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].PublishedAt > merged[j].PublishedAt
+	})
+
+	return merged, nil
+}
+
+// dedupeNewsItems drops items sharing a URL and title, keeping the first
+// occurrence (earlier sources in s.sources win ties).
+func dedupeNewsItems(items []NewsItem) []NewsItem {
+	seen := make(map[string]bool, len(items))
+	out := make([]NewsItem, 0, len(items))
+	for _, item := range items {
+		key := item.URL + "|" + item.Title
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, item)
+	}
+	return out
 }
@@ -0,0 +1,171 @@
+package news
+
+import (
+	"HyPrism/internal/news/httpcache"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Renderer turns an Article's raw markdown body into a presentation format
+// (plain text, ANSI for a TUI, or HTML for the desktop news panel).
+type Renderer interface {
+	Render(article Article) (string, error)
+}
+
+var (
+	mdHeading  = regexp.MustCompile(`(?m)^#{1,6}\s*(.+)$`)
+	mdBold     = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	mdItalic   = regexp.MustCompile(`\*(.+?)\*`)
+	mdLink     = regexp.MustCompile(`\[([^\]]*)\]\(([^)]*)\)`)
+	mdListItem = regexp.MustCompile(`(?m)^[-*]\s+`)
+)
+
+// PlainRenderer strips markdown syntax down to readable plain text. It has
+// no external dependency and cannot fail, making it the ultimate fallback.
+type PlainRenderer struct{}
+
+func (PlainRenderer) Render(article Article) (string, error) {
+	body := article.Body
+	body = mdLink.ReplaceAllString(body, "$1")
+	body = mdBold.ReplaceAllString(body, "$1")
+	body = mdItalic.ReplaceAllString(body, "$1")
+	body = mdHeading.ReplaceAllString(body, "$1")
+	body = mdListItem.ReplaceAllString(body, "- ")
+	return strings.TrimSpace(body), nil
+}
+
+// ANSI escape codes used by ANSIRenderer.
+const (
+	ansiBold  = "\x1b[1m"
+	ansiDim   = "\x1b[2m"
+	ansiUndl  = "\x1b[4m"
+	ansiReset = "\x1b[0m"
+)
+
+// ANSIRenderer renders markdown for a terminal UI: bold headings, underlined
+// links, and dimmed emphasis.
+type ANSIRenderer struct{}
+
+func (ANSIRenderer) Render(article Article) (string, error) {
+	body := article.Body
+	body = mdHeading.ReplaceAllString(body, ansiBold+"$1"+ansiReset)
+	body = mdLink.ReplaceAllString(body, ansiUndl+"$1"+ansiReset+" ($2)")
+	body = mdBold.ReplaceAllString(body, ansiBold+"$1"+ansiReset)
+	body = mdItalic.ReplaceAllString(body, ansiDim+"$1"+ansiReset)
+	body = mdListItem.ReplaceAllString(body, "  • ")
+	return strings.TrimSpace(body), nil
+}
+
+// HTMLRenderer renders markdown into a minimal HTML fragment suitable for
+// embedding in the desktop app's news panel.
+type HTMLRenderer struct{}
+
+func (HTMLRenderer) Render(article Article) (string, error) {
+	escaped := html.EscapeString(article.Body)
+	escaped = mdHeading.ReplaceAllString(escaped, "<h3>$1</h3>")
+	escaped = mdLink.ReplaceAllString(escaped, `<a href="$2">$1</a>`)
+	escaped = mdBold.ReplaceAllString(escaped, "<strong>$1</strong>")
+	escaped = mdItalic.ReplaceAllString(escaped, "<em>$1</em>")
+	escaped = mdListItem.ReplaceAllString(escaped, "<li>")
+
+	var out bytes.Buffer
+	for _, line := range strings.Split(escaped, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		out.WriteString("<p>")
+		out.WriteString(line)
+		out.WriteString("</p>\n")
+	}
+
+	return out.String(), nil
+}
+
+// RemoteMarkdownRenderer renders markdown via GitHub's public markdown
+// rendering API. It is meant to be used as FallbackRenderer.Primary so a
+// richer, upstream-maintained renderer is preferred when reachable.
+type RemoteMarkdownRenderer struct{}
+
+func (RemoteMarkdownRenderer) Render(article Article) (string, error) {
+	payload, err := json.Marshal(map[string]string{"text": article.Body, "mode": "markdown"})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "https://api.github.com/markdown", bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "HyPrism/1.0")
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to reach markdown API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("markdown API returned status %d", resp.StatusCode)
+	}
+
+	body, err := httpcache.ReadLimited(resp)
+	if err != nil {
+		return "", err
+	}
+
+	return string(body), nil
+}
+
+// FallbackRenderer tries Primary first and falls back to Secondary if
+// Primary errors, mirroring how tools like GoBlog and Teafolio fall back to
+// an internal markdown renderer when an external rendering API is down.
+type FallbackRenderer struct {
+	Primary   Renderer
+	Secondary Renderer
+}
+
+func (f FallbackRenderer) Render(article Article) (string, error) {
+	if f.Primary != nil {
+		if out, err := f.Primary.Render(article); err == nil {
+			return out, nil
+		}
+	}
+	return f.Secondary.Render(article)
+}
+
+// renderedArticleTTL bounds how long a rendered article is reused before
+// RenderArticle re-renders it.
+const renderedArticleTTL = 24 * time.Hour
+
+// RenderArticle renders article via renderer, caching the result under the
+// same persistent cache used for listings so repeat views (e.g. reopening
+// the news panel) don't re-render or re-fetch from a remote renderer.
+func RenderArticle(article Article, renderer Renderer) (string, error) {
+	cache, cacheErr := sourceHTTPCache()
+	key := fmt.Sprintf("article-render:%s:%T", article.Slug, renderer)
+
+	if cacheErr == nil {
+		if body, ok := cache.Get(key); ok {
+			return string(body), nil
+		}
+	}
+
+	out, err := renderer.Render(article)
+	if err != nil {
+		return "", err
+	}
+
+	if cacheErr == nil {
+		_ = cache.Set(key, []byte(out), renderedArticleTTL)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,331 @@
+package news
+
+import (
+	"HyPrism/internal/env"
+	"HyPrism/internal/logging"
+	"HyPrism/internal/news/httpcache"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	httpCacheOnce  sync.Once
+	sharedCache    *httpcache.Cache
+	sharedCacheErr error
+)
+
+// sharedHTTPClient is reused across every source fetch instead of allocating
+// a fresh *http.Client (and its transport/connection pool) per call.
+var sharedHTTPClient = &http.Client{Timeout: 15 * time.Second}
+
+// sourceHTTPCache returns the persistent HTTP cache shared by all sources,
+// opened lazily under env.GetCacheDir() so cached feed responses survive
+// process restarts.
+func sourceHTTPCache() (*httpcache.Cache, error) {
+	httpCacheOnce.Do(func() {
+		sharedCache, sharedCacheErr = httpcache.Open(filepath.Join(env.GetCacheDir(), "news"))
+	})
+	return sharedCache, sharedCacheErr
+}
+
+// fetchCached issues req through the shared persistent HTTP cache, falling
+// back to an uncached request if the cache directory can't be opened.
+func fetchCached(client *http.Client, req *http.Request) ([]byte, error) {
+	cache, err := sourceHTTPCache()
+	if err != nil {
+		resp, doErr := client.Do(req)
+		if doErr != nil {
+			return nil, doErr
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		}
+		return httpcache.ReadLimited(resp)
+	}
+	return cache.Fetch(client, req)
+}
+
+// Source is a pluggable news provider. Implementations fetch and normalize
+// items from one upstream (a blog API, a subreddit, a feed mirror, ...) so
+// NewsService can fan out across all of them and merge the result.
+type Source interface {
+	Name() string
+	Fetch(ctx context.Context, limit int) ([]NewsItem, error)
+}
+
+// blogSource wraps the existing Hytale blog API behavior. strict points at
+// the owning NewsService's strictSchema field so toggling WithStrictSchema
+// after construction takes effect on the next fetch.
+type blogSource struct {
+	strict *bool
+}
+
+func (*blogSource) Name() string { return "hytale-blog" }
+
+func (b *blogSource) Fetch(ctx context.Context, limit int) ([]NewsItem, error) {
+	strict := b.strict != nil && *b.strict
+
+	items, stats, err := FetchNewsContext(ctx, limit, strict)
+	if err != nil {
+		return nil, err
+	}
+	if stats.Skipped > 0 {
+		logging.Logger().Warn("hytale blog returned malformed news items", "skipped", stats.Skipped, "reasons", stats.Reasons)
+	}
+	return items, nil
+}
+
+// redditSource fetches the newest posts from a subreddit's public JSON listing.
+type redditSource struct {
+	subreddit string
+}
+
+func (r redditSource) Name() string { return "reddit-" + r.subreddit }
+
+func (r redditSource) Fetch(ctx context.Context, limit int) ([]NewsItem, error) {
+	reqURL := fmt.Sprintf("https://www.reddit.com/r/%s/new.json?limit=%d", r.subreddit, limit)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "HyPrism/1.0")
+	req.Header.Set("Accept", "application/json")
+
+	body, err := fetchCached(sharedHTTPClient, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch r/%s: %w", r.subreddit, err)
+	}
+
+	var listing struct {
+		Data struct {
+			Children []struct {
+				Data struct {
+					Title      string  `json:"title"`
+					Author     string  `json:"author"`
+					Permalink  string  `json:"permalink"`
+					Selftext   string  `json:"selftext"`
+					CreatedUTC float64 `json:"created_utc"`
+				} `json:"data"`
+			} `json:"children"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(body, &listing); err != nil {
+		return nil, fmt.Errorf("failed to parse r/%s response: %w", r.subreddit, err)
+	}
+
+	items := make([]NewsItem, 0, len(listing.Data.Children))
+	for _, child := range listing.Data.Children {
+		post := child.Data
+		items = append(items, NewsItem{
+			Title:       post.Title,
+			BodyExcerpt: post.Selftext,
+			Excerpt:     post.Selftext,
+			URL:         "https://www.reddit.com" + post.Permalink,
+			PublishedAt: time.Unix(int64(post.CreatedUTC), 0).UTC().Format(time.RFC3339),
+			Slug:        strings.Trim(post.Permalink, "/"),
+			Author:      post.Author,
+		})
+	}
+
+	return items, nil
+}
+
+// discordMirrorSource reads a community-maintained JSON mirror of a Discord
+// announcements channel. These mirrors are run by the community, not Hytale,
+// so items are best-effort and this source is expected to fail gracefully.
+type discordMirrorSource struct {
+	name string
+	url  string
+}
+
+func (d discordMirrorSource) Name() string { return d.name }
+
+func (d discordMirrorSource) Fetch(ctx context.Context, limit int) ([]NewsItem, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	body, err := fetchCached(sharedHTTPClient, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", d.name, err)
+	}
+
+	var messages []struct {
+		Content   string `json:"content"`
+		Author    string `json:"author"`
+		Timestamp string `json:"timestamp"`
+		URL       string `json:"url"`
+	}
+	if err := json.Unmarshal(body, &messages); err != nil {
+		return nil, fmt.Errorf("failed to parse %s response: %w", d.name, err)
+	}
+
+	if len(messages) > limit {
+		messages = messages[:limit]
+	}
+
+	items := make([]NewsItem, 0, len(messages))
+	for _, msg := range messages {
+		title := msg.Content
+		if len(title) > 80 {
+			title = title[:80] + "…"
+		}
+		items = append(items, NewsItem{
+			Title:       title,
+			BodyExcerpt: msg.Content,
+			Excerpt:     msg.Content,
+			URL:         msg.URL,
+			PublishedAt: msg.Timestamp,
+			Author:      msg.Author,
+		})
+	}
+
+	return items, nil
+}
+
+// rssSource reads a generic RSS 2.0 or Atom feed, recognizing whichever
+// element set the response actually contains.
+type rssSource struct {
+	name string
+	url  string
+}
+
+func (r rssSource) Name() string { return r.name }
+
+func (r rssSource) Fetch(ctx context.Context, limit int) ([]NewsItem, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml")
+
+	body, err := fetchCached(sharedHTTPClient, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", r.name, err)
+	}
+
+	var feed struct {
+		Channel struct {
+			Items []struct {
+				Title   string `xml:"title"`
+				Link    string `xml:"link"`
+				GUID    string `xml:"guid"`
+				PubDate string `xml:"pubDate"`
+			} `xml:"item"`
+		} `xml:"channel"`
+		Entries []struct {
+			Title string `xml:"title"`
+			ID    string `xml:"id"`
+			Link  struct {
+				Href string `xml:"href,attr"`
+			} `xml:"link"`
+			Published string `xml:"published"`
+		} `xml:"entry"`
+	}
+
+	if err := xml.Unmarshal(body, &feed); err != nil {
+		return nil, fmt.Errorf("failed to parse %s feed: %w", r.name, err)
+	}
+
+	var items []NewsItem
+	for _, entry := range feed.Channel.Items {
+		publishedAt := entry.PubDate
+		if parsed, err := time.Parse(time.RFC1123Z, entry.PubDate); err == nil {
+			publishedAt = parsed.UTC().Format(time.RFC3339)
+		}
+		items = append(items, NewsItem{
+			Title:       entry.Title,
+			URL:         entry.Link,
+			Slug:        entry.GUID,
+			PublishedAt: publishedAt,
+		})
+	}
+	for _, entry := range feed.Entries {
+		items = append(items, NewsItem{
+			Title:       entry.Title,
+			URL:         entry.Link.Href,
+			Slug:        entry.ID,
+			PublishedAt: entry.Published,
+		})
+	}
+
+	if len(items) > limit {
+		items = items[:limit]
+	}
+
+	return items, nil
+}
+
+// cachedSource wraps a Source with its own TTL cache, so a source that is
+// slow or rate-limited doesn't force a refetch of every other source, and so
+// a transient failure falls back to the last good result instead of
+// dropping that source from the merged feed.
+type cachedSource struct {
+	Source
+	ttl      time.Duration
+	mu       sync.Mutex
+	cache    []NewsItem
+	cachedAt time.Time
+}
+
+// withCache returns src wrapped with a per-source cache with the given TTL.
+func withCache(src Source, ttl time.Duration) Source {
+	return &cachedSource{Source: src, ttl: ttl}
+}
+
+func (c *cachedSource) Fetch(ctx context.Context, limit int) ([]NewsItem, error) {
+	c.mu.Lock()
+	if time.Since(c.cachedAt) < c.ttl && len(c.cache) > 0 {
+		items := c.cache
+		c.mu.Unlock()
+		if len(items) > limit {
+			return items[:limit], nil
+		}
+		return items, nil
+	}
+	c.mu.Unlock()
+
+	return c.FetchFresh(ctx, limit)
+}
+
+// freshFetcher is implemented by sources that maintain their own cache and
+// can be asked to bypass it for a single call, such as NewsService.Watch's
+// poll loop, which would otherwise be floored by the source's own TTL.
+type freshFetcher interface {
+	FetchFresh(ctx context.Context, limit int) ([]NewsItem, error)
+}
+
+// FetchFresh always fetches from the wrapped Source, ignoring any cached
+// result still within ttl, and refreshes the cache with the outcome. A
+// failed fetch falls back to the last good cached result, same as Fetch.
+func (c *cachedSource) FetchFresh(ctx context.Context, limit int) ([]NewsItem, error) {
+	items, err := c.Source.Fetch(ctx, limit)
+	if err != nil {
+		c.mu.Lock()
+		stale := c.cache
+		c.mu.Unlock()
+		if len(stale) > 0 {
+			return stale, nil
+		}
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.cache = items
+	c.cachedAt = time.Now()
+	c.mu.Unlock()
+
+	return items, nil
+}
@@ -0,0 +1,161 @@
+package news
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// NewsEventKind identifies how an item changed between successive Watch polls.
+type NewsEventKind int
+
+const (
+	NewsAdded NewsEventKind = iota
+	NewsUpdated
+	NewsRemoved
+)
+
+// NewsEvent reports a single item entering, changing, or leaving the merged
+// feed, so push-based consumers (tray notifications, the news panel, a sound
+// cue) can react without each running its own poll loop.
+type NewsEvent struct {
+	Kind NewsEventKind
+	Item NewsItem
+}
+
+// watchLimit caps how many merged items Watch considers per poll; items are
+// sorted by PublishedAt descending, so a small limit is enough to catch new
+// or edited posts without re-scanning the entire history every tick.
+const watchLimit = 50
+
+// Watch polls the service's sources every interval and returns a channel of
+// NewsEvent describing additions, updates (Title or BodyExcerpt changed),
+// and removals compared to the previous poll. Identity is Slug+PublishedAt.
+// Each poll uses RefreshNewsContext, bypassing the aggregate and per-source
+// caches, so interval is not floored by a source's own cache TTL; the shared
+// on-disk HTTP cache still reuses a response until its Cache-Control /
+// Expires lifetime (10 minutes by default) runs out. Every event is also fanned out to
+// Subscribe() listeners, so UI components can observe changes without
+// calling Watch themselves. The returned channel is closed when ctx is done.
+func (s *NewsService) Watch(ctx context.Context, interval time.Duration) <-chan NewsEvent {
+	out := make(chan NewsEvent)
+
+	go func() {
+		defer close(out)
+
+		snapshot := s.watchSnapshot(ctx)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				next := s.watchSnapshot(ctx)
+
+				for key, item := range next {
+					prev, existed := snapshot[key]
+					switch {
+					case !existed:
+						s.emitWatchEvent(ctx, out, NewsEvent{Kind: NewsAdded, Item: item})
+					case prev.Title != item.Title || prev.BodyExcerpt != item.BodyExcerpt:
+						s.emitWatchEvent(ctx, out, NewsEvent{Kind: NewsUpdated, Item: item})
+					}
+				}
+
+				for key, item := range snapshot {
+					if _, stillPresent := next[key]; !stillPresent {
+						s.emitWatchEvent(ctx, out, NewsEvent{Kind: NewsRemoved, Item: item})
+					}
+				}
+
+				snapshot = next
+			}
+		}
+	}()
+
+	return out
+}
+
+// watchSnapshot fetches the current merged feed keyed by newsItemKey, so it
+// can be diffed against the previous poll. A fetch error yields an empty
+// snapshot rather than failing Watch outright; the next tick will retry.
+func (s *NewsService) watchSnapshot(ctx context.Context) map[string]NewsItem {
+	items, err := s.RefreshNewsContext(ctx, watchLimit)
+	if err != nil {
+		return map[string]NewsItem{}
+	}
+
+	snapshot := make(map[string]NewsItem, len(items))
+	for _, item := range items {
+		snapshot[newsItemKey(item)] = item
+	}
+	return snapshot
+}
+
+// emitWatchEvent fans event out to Subscribe() listeners and then delivers
+// it on out, giving up if ctx is cancelled first.
+func (s *NewsService) emitWatchEvent(ctx context.Context, out chan<- NewsEvent, event NewsEvent) {
+	publishEvent(event)
+
+	select {
+	case out <- event:
+	case <-ctx.Done():
+	}
+}
+
+// newsItemKey returns the identity used to match items across Watch polls:
+// Slug identifies a post, and PublishedAt guards against a slug being reused
+// for a different publish.
+func newsItemKey(item NewsItem) string {
+	return item.Slug + "|" + item.PublishedAt
+}
+
+var (
+	watchersMu sync.RWMutex
+	watchers   []chan NewsEvent
+)
+
+// Subscribe registers a new listener and returns a channel that receives
+// every NewsEvent emitted by any active Watch call after this point. The
+// channel is buffered; a subscriber that falls behind drops events rather
+// than blocking Watch. Call Unsubscribe when the listener is done.
+func Subscribe() <-chan NewsEvent {
+	ch := make(chan NewsEvent, 32)
+
+	watchersMu.Lock()
+	watchers = append(watchers, ch)
+	watchersMu.Unlock()
+
+	return ch
+}
+
+// Unsubscribe removes a channel previously returned by Subscribe and closes it.
+func Unsubscribe(ch <-chan NewsEvent) {
+	watchersMu.Lock()
+	defer watchersMu.Unlock()
+
+	for i, sub := range watchers {
+		if sub == ch {
+			watchers = append(watchers[:i], watchers[i+1:]...)
+			close(sub)
+			return
+		}
+	}
+}
+
+// publishEvent sends event to every current Subscribe listener, dropping it
+// for subscribers whose buffer is full instead of blocking Watch.
+func publishEvent(event NewsEvent) {
+	watchersMu.RLock()
+	defer watchersMu.RUnlock()
+
+	for _, sub := range watchers {
+		select {
+		case sub <- event:
+		default:
+		}
+	}
+}
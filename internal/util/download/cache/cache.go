@@ -0,0 +1,98 @@
+// Package cache implements the content-addressed object store and lookup index
+// backing download.FetchCached: completed downloads are stored once under their
+// SHA-256 digest and subsequent requests for the same URL can be revalidated or
+// reused instead of re-downloaded.
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Entry records where a previously downloaded URL ended up in the content-addressed
+// object store, along with the validators needed to conditionally revalidate it.
+type Entry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	Sha256       string    `json:"sha256"`
+	Size         int64     `json:"size"`
+	StoredAt     time.Time `json:"storedAt"`
+}
+
+// Index is a small on-disk JSON mapping of request URL to the Entry produced for
+// it, so repeated fetches of the same URL can be revalidated instead of
+// re-downloaded from scratch.
+type Index struct {
+	path    string
+	mu      sync.Mutex
+	entries map[string]Entry
+}
+
+// Open loads (or creates) the index file at <dir>/index.json.
+func Open(dir string) (*Index, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	idx := &Index{
+		path:    filepath.Join(dir, "index.json"),
+		entries: map[string]Entry{},
+	}
+
+	data, err := os.ReadFile(idx.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return idx, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &idx.entries); err != nil {
+		return nil, fmt.Errorf("failed to parse cache index: %w", err)
+	}
+
+	return idx, nil
+}
+
+// Lookup returns the Entry previously stored for url, if any.
+func (idx *Index) Lookup(url string) (Entry, bool) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	entry, ok := idx.entries[url]
+	return entry, ok
+}
+
+// Put records (or replaces) the Entry for entry.URL and persists the index to disk.
+func (idx *Index) Put(entry Entry) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.entries[entry.URL] = entry
+	return idx.save()
+}
+
+func (idx *Index) save() error {
+	data, err := json.MarshalIndent(idx.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := idx.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, idx.path)
+}
+
+// ObjectPath returns the content-addressed path for a digest under baseDir, e.g.
+// <baseDir>/ab/ab54c1...
+func ObjectPath(baseDir, sha256Digest string) string {
+	if len(sha256Digest) < 2 {
+		return filepath.Join(baseDir, sha256Digest)
+	}
+	return filepath.Join(baseDir, sha256Digest[:2], sha256Digest)
+}
@@ -0,0 +1,258 @@
+package download
+
+import (
+	"HyPrism/internal/env"
+	"HyPrism/internal/util/download/cache"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	cacheIndexOnce sync.Once
+	cacheIndex     *cache.Index
+	cacheIndexErr  error
+)
+
+func cacheObjectsDir() string {
+	return filepath.Join(env.GetCacheDir(), "objects")
+}
+
+func openCacheIndex() (*cache.Index, error) {
+	cacheIndexOnce.Do(func() {
+		cacheIndex, cacheIndexErr = cache.Open(cacheObjectsDir())
+	})
+	return cacheIndex, cacheIndexErr
+}
+
+// FetchCached downloads url, deduplicating identical assets across callers (e.g.
+// multiple instances sharing the same JRE tarball) via a content-addressed cache
+// under env.GetCacheDir()/objects. It first consults the on-disk index for a prior
+// (url, etag, last-modified) -> sha256 mapping, discarding it as a miss when
+// expectedSha256 is non-empty and disagrees with the indexed digest; on a hit it
+// issues a conditional request and, on a 304, re-verifies the cached object's
+// digest before hard-linking (falling back to a copy across filesystems) it into
+// dest without re-downloading. On a miss it downloads fresh, verifies against
+// expectedSha256 when non-empty, stores the result under its digest, records it
+// in the index, and hard-links it into dest. It returns the path to the object
+// inside the cache store.
+func FetchCached(
+	ctx context.Context,
+	url string,
+	expectedSha256 string,
+	dest string,
+	callback func(stage string, progress float64, message string, currentFile string, speed string, downloaded, total int64),
+) (string, error) {
+	idx, err := openCacheIndex()
+	if err != nil {
+		return "", fmt.Errorf("failed to open cache index: %w", err)
+	}
+
+	var prevEntry cache.Entry
+	hasPrev := false
+	if entry, ok := idx.Lookup(url); ok {
+		// An index entry that disagrees with the caller's expected digest means
+		// the content behind url has rotated (or the index was tampered with);
+		// treat it as a miss so the conditional request below is skipped and a
+		// fresh copy is downloaded and verified instead of trusted as-is.
+		if expectedSha256 == "" || strings.EqualFold(entry.Sha256, expectedSha256) {
+			if _, statErr := os.Stat(cache.ObjectPath(cacheObjectsDir(), entry.Sha256)); statErr == nil {
+				prevEntry, hasPrev = entry, true
+			}
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "*/*")
+	req.Header.Set("Accept-Encoding", "identity")
+	req.Header.Set("User-Agent", "HyPrism/1.0")
+	if hasPrev {
+		if prevEntry.ETag != "" {
+			req.Header.Set("If-None-Match", prevEntry.ETag)
+		}
+		if prevEntry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", prevEntry.LastModified)
+		}
+	}
+
+	resp, err := GetSharedClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if hasPrev && resp.StatusCode == http.StatusNotModified {
+		objPath := cache.ObjectPath(cacheObjectsDir(), prevEntry.Sha256)
+		if err := verifyObjectDigest(objPath, prevEntry.Sha256); err != nil {
+			return "", fmt.Errorf("cached object for %s failed integrity check: %w", url, err)
+		}
+		prevEntry.StoredAt = time.Now()
+		if err := idx.Put(prevEntry); err != nil {
+			return "", err
+		}
+		return objPath, linkOrCopy(objPath, dest)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	if err := os.MkdirAll(cacheObjectsDir(), 0755); err != nil {
+		return "", err
+	}
+	tmpFile, err := os.CreateTemp(cacheObjectsDir(), "hyprism-cache-*")
+	if err != nil {
+		return "", err
+	}
+	tmpDest := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(tmpDest)
+
+	digest, size, err := streamToFileWithDigest(resp, tmpDest, dest, expectedSha256, callback)
+	if err != nil {
+		return "", err
+	}
+
+	objPath := cache.ObjectPath(cacheObjectsDir(), digest)
+	if err := os.MkdirAll(filepath.Dir(objPath), 0755); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmpDest, objPath); err != nil {
+		return "", err
+	}
+
+	entry := cache.Entry{
+		URL:          url,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Sha256:       digest,
+		Size:         size,
+		StoredAt:     time.Now(),
+	}
+	if err := idx.Put(entry); err != nil {
+		return "", err
+	}
+
+	return objPath, linkOrCopy(objPath, dest)
+}
+
+// streamToFileWithDigest copies resp.Body into tmpDest while hashing it, reporting
+// progress through callback at the same cadence as the rest of the package, and
+// verifies the result against expectedSha256 when non-empty.
+func streamToFileWithDigest(
+	resp *http.Response,
+	tmpDest string,
+	dest string,
+	expectedSha256 string,
+	callback func(stage string, progress float64, message string, currentFile string, speed string, downloaded, total int64),
+) (digest string, size int64, err error) {
+	file, err := os.Create(tmpDest)
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	writer := io.MultiWriter(file, hasher)
+
+	total := resp.ContentLength
+	var downloaded int64
+
+	buf := make([]byte, 32*1024)
+	lastUpdate := time.Now()
+	lastDownloaded := downloaded
+
+	for {
+		n, readErr := resp.Body.Read(buf)
+		if n > 0 {
+			if _, writeErr := writer.Write(buf[:n]); writeErr != nil {
+				return "", 0, writeErr
+			}
+			downloaded += int64(n)
+
+			if callback != nil && time.Since(lastUpdate) >= 100*time.Millisecond {
+				speed := float64(downloaded-lastDownloaded) / time.Since(lastUpdate).Seconds()
+				progress := float64(downloaded) / float64(total) * 100
+				callback("download", progress, "Downloading...", filepath.Base(dest), formatSpeed(speed), downloaded, total)
+				lastUpdate = time.Now()
+				lastDownloaded = downloaded
+			}
+		}
+		if readErr != nil {
+			if readErr == io.EOF {
+				break
+			}
+			return "", 0, readErr
+		}
+	}
+
+	digest = hex.EncodeToString(hasher.Sum(nil))
+	if expectedSha256 != "" && !strings.EqualFold(digest, expectedSha256) {
+		return "", 0, fmt.Errorf("checksum mismatch: expected %s, got %s", expectedSha256, digest)
+	}
+
+	return digest, downloaded, nil
+}
+
+// verifyObjectDigest re-hashes the object stored at path and confirms it
+// matches expectedDigest, catching on-disk corruption (or tampering) of a
+// content-addressed object before it's handed back to a caller unverified.
+func verifyObjectDigest(path, expectedDigest string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return err
+	}
+
+	digest := hex.EncodeToString(hasher.Sum(nil))
+	if !strings.EqualFold(digest, expectedDigest) {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", expectedDigest, digest)
+	}
+	return nil
+}
+
+// linkOrCopy materializes the cache object at src into dest, preferring a hard
+// link (instant, no extra disk usage) and falling back to a copy when src and
+// dest are on different filesystems.
+func linkOrCopy(src, dest string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+
+	os.Remove(dest)
+
+	if err := os.Link(src, dest); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
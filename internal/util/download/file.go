@@ -1,15 +1,21 @@
 package download
 
 import (
+	"HyPrism/internal/events"
+	"HyPrism/internal/logging"
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"time"
 )
 
@@ -29,6 +35,8 @@ func DownloadWithProgress(
 ) error {
 	var lastErr error
 
+	events.Publish(events.DownloadStarted{URL: url, Dest: dest})
+
 	for attempt := 1; attempt <= maxRetries; attempt++ {
 		err := attemptDownload(dest, url, stage, progressWeight, callback)
 		if err == nil {
@@ -36,14 +44,15 @@ func DownloadWithProgress(
 		}
 
 		lastErr = err
-		fmt.Printf("Download attempt %d failed: %v\n", attempt, err)
+		logging.Logger().Warn("download attempt failed", "url", url, "attempt", attempt, "error", err)
+		events.Publish(events.DownloadRetry{URL: url, Attempt: attempt, Err: err})
 
-		// If certificate error and trusted source (github/adoptium), try with insecure client
+		// If certificate error and trusted source (github/adoptium), retry against the vendored CA bundle
 		if attempt == 1 && isCertError(err) && isTrustedSource(url) {
-			fmt.Println("Certificate verification failed, retrying with insecure client for trusted source...")
-			err = attemptDownloadInsecure(dest, url, stage, progressWeight, callback)
+			logging.Logger().Info("certificate verification failed, retrying against vendored CA bundle", "url", url)
+			err = attemptDownloadWithClient(pinnedClientFor(url), dest, url, stage, progressWeight, callback)
 			if err == nil {
-				fmt.Println("Download successful with insecure client")
+				logging.Logger().Info("download succeeded with pinned CA bundle", "url", url)
 				return nil
 			}
 			lastErr = err
@@ -54,72 +63,76 @@ func DownloadWithProgress(
 		}
 	}
 
+	events.Publish(events.DownloadFailed{URL: url, Err: lastErr})
 	return fmt.Errorf("download failed after %d attempts: %w", maxRetries, lastErr)
 }
 
-// isCertError checks if error is TLS certificate related
-func isCertError(err error) bool {
-	if err == nil {
-		return false
-	}
-	errStr := err.Error()
-	return contains(errStr, "certificate") || contains(errStr, "x509") || contains(errStr, "tls")
-}
+// DownloadWithVerification downloads a file the same way as DownloadWithProgress,
+// but additionally hashes the stream as it is written and rejects (deletes) the
+// downloaded file before the final rename if its SHA-256 digest does not match
+// expectedSha256. Pass an empty expectedSha256 to skip verification.
+func DownloadWithVerification(
+	dest string,
+	url string,
+	expectedSha256 string,
+	stage string,
+	progressWeight float64,
+	callback func(stage string, progress float64, message string, currentFile string, speed string, downloaded, total int64),
+) error {
+	var lastErr error
 
-// isTrustedSource checks if URL is from trusted sources
-func isTrustedSource(url string) bool {
-	trustedDomains := []string{
-		"github.com",
-		"githubusercontent.com",
-		"adoptium.net",
-		"itch.zone",
-	}
-	for _, domain := range trustedDomains {
-		if contains(url, domain) {
-			return true
+	events.Publish(events.DownloadStarted{URL: url, Dest: dest})
+
+	for attempt := 1; attempt <= maxRetries; attempt++ {
+		err := attemptDownloadVerified(createOptimizedClient(), dest, url, expectedSha256, stage, progressWeight, callback)
+		if err == nil {
+			return nil
 		}
-	}
-	return false
-}
 
-// contains checks if string contains substring (case-insensitive)
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) && 
-		(s[:len(substr)] == substr || s[len(s)-len(substr):] == substr || 
-		findSubstring(s, substr)))
-}
+		lastErr = err
+		logging.Logger().Warn("verified download attempt failed", "url", url, "attempt", attempt, "error", err)
+		events.Publish(events.DownloadRetry{URL: url, Attempt: attempt, Err: err})
 
-func findSubstring(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
+		if attempt == 1 && isCertError(err) && isTrustedSource(url) {
+			logging.Logger().Info("certificate verification failed, retrying against vendored CA bundle", "url", url)
+			err = attemptDownloadVerified(pinnedClientFor(url), dest, url, expectedSha256, stage, progressWeight, callback)
+			if err == nil {
+				logging.Logger().Info("download succeeded with pinned CA bundle", "url", url)
+				return nil
+			}
+			lastErr = err
+		}
+
+		if attempt < maxRetries {
+			time.Sleep(retryDelay)
 		}
 	}
-	return false
+
+	events.Publish(events.DownloadFailed{URL: url, Err: lastErr})
+	return fmt.Errorf("download failed after %d attempts: %w", maxRetries, lastErr)
 }
 
-func attemptDownload(
+// attemptDownloadVerified streams the response body into tempDest while feeding a
+// sha256.Hash, then checks the resulting digest before renaming into place. Unlike
+// attemptDownloadWithClient it does not resume partial downloads, since the digest
+// must cover the file from byte zero.
+func attemptDownloadVerified(
+	client *http.Client,
 	dest string,
 	url string,
+	expectedSha256 string,
 	stage string,
 	progressWeight float64,
 	callback func(stage string, progress float64, message string, currentFile string, speed string, downloaded, total int64),
 ) error {
-	client := createOptimizedClient()
-
 	tempDest := dest + ".tmp"
 
 	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	// Check if partial file exists
-	var resumeFrom int64 = 0
-	if stat, err := os.Stat(tempDest); err == nil {
-		resumeFrom = stat.Size()
-	}
+	os.Remove(tempDest)
 
-	// Create request with context for timeout control
 	ctx, cancel := context.WithTimeout(context.Background(), downloadTimeout)
 	defer cancel()
 
@@ -133,35 +146,26 @@ func attemptDownload(
 	req.Header.Set("Connection", "keep-alive")
 	req.Header.Set("User-Agent", "HyPrism/1.0")
 
-	if resumeFrom > 0 {
-		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", resumeFrom))
-	}
-
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
-	// Handle resume
-	var file *os.File
-	if resp.StatusCode == http.StatusPartialContent {
-		file, err = os.OpenFile(tempDest, os.O_APPEND|os.O_WRONLY, 0644)
-	} else {
-		file, err = os.Create(tempDest)
-		resumeFrom = 0
-	}
+	file, err := os.Create(tempDest)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
 
-	total := resp.ContentLength + resumeFrom
-	downloaded := resumeFrom
+	hasher := sha256.New()
+	writer := io.MultiWriter(file, hasher)
+
+	total := resp.ContentLength
+	var downloaded int64
 
 	buf := make([]byte, 32*1024)
 	lastUpdate := time.Now()
@@ -170,18 +174,21 @@ func attemptDownload(
 	for {
 		n, readErr := resp.Body.Read(buf)
 		if n > 0 {
-			if _, writeErr := file.Write(buf[:n]); writeErr != nil {
+			if _, writeErr := writer.Write(buf[:n]); writeErr != nil {
+				file.Close()
+				os.Remove(tempDest)
 				return writeErr
 			}
 			downloaded += int64(n)
 
-			// Update progress every 100ms
-			if time.Since(lastUpdate) >= 100*time.Millisecond && callback != nil {
+			if time.Since(lastUpdate) >= 100*time.Millisecond {
 				speed := float64(downloaded-lastDownloaded) / time.Since(lastUpdate).Seconds()
-				speedStr := formatSpeed(speed)
-				progress := float64(downloaded) / float64(total) * 100 * progressWeight
+				events.Publish(events.DownloadProgress{URL: url, Downloaded: downloaded, Total: total, SpeedBps: speed})
 
-				callback(stage, progress, "Downloading...", filepath.Base(dest), speedStr, downloaded, total)
+				if callback != nil {
+					progress := float64(downloaded) / float64(total) * 100 * progressWeight
+					callback(stage, progress, "Downloading...", filepath.Base(dest), formatSpeed(speed), downloaded, total)
+				}
 
 				lastUpdate = time.Now()
 				lastDownloaded = downloaded
@@ -191,13 +198,25 @@ func attemptDownload(
 			if readErr == io.EOF {
 				break
 			}
+			file.Close()
+			os.Remove(tempDest)
 			return readErr
 		}
 	}
 
-	file.Close()
+	if err := file.Close(); err != nil {
+		os.Remove(tempDest)
+		return err
+	}
+
+	if expectedSha256 != "" {
+		actual := hex.EncodeToString(hasher.Sum(nil))
+		if !strings.EqualFold(actual, expectedSha256) {
+			os.Remove(tempDest)
+			return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", filepath.Base(dest), expectedSha256, actual)
+		}
+	}
 
-	// Rename temp file to final destination
 	if err := os.Rename(tempDest, dest); err != nil {
 		return err
 	}
@@ -205,16 +224,65 @@ func attemptDownload(
 	return nil
 }
 
-// attemptDownloadInsecure is identical to attemptDownload but uses insecure client
-func attemptDownloadInsecure(
+// isCertError checks if error is TLS certificate related
+func isCertError(err error) bool {
+	if err == nil {
+		return false
+	}
+	errStr := err.Error()
+	return contains(errStr, "certificate") || contains(errStr, "x509") || contains(errStr, "tls")
+}
+
+// isTrustedSource checks if URL is from trusted sources
+func isTrustedSource(url string) bool {
+	trustedDomains := []string{
+		"github.com",
+		"githubusercontent.com",
+		"adoptium.net",
+		"itch.zone",
+	}
+	for _, domain := range trustedDomains {
+		if contains(url, domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// contains checks if string contains substring (case-insensitive)
+func contains(s, substr string) bool {
+	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) && 
+		(s[:len(substr)] == substr || s[len(s)-len(substr):] == substr || 
+		findSubstring(s, substr)))
+}
+
+func findSubstring(s, substr string) bool {
+	for i := 0; i <= len(s)-len(substr); i++ {
+		if s[i:i+len(substr)] == substr {
+			return true
+		}
+	}
+	return false
+}
+
+func attemptDownload(
 	dest string,
 	url string,
 	stage string,
 	progressWeight float64,
 	callback func(stage string, progress float64, message string, currentFile string, speed string, downloaded, total int64),
 ) error {
-	client := insecureClient
+	return attemptDownloadWithClient(createOptimizedClient(), dest, url, stage, progressWeight, callback)
+}
 
+func attemptDownloadWithClient(
+	client *http.Client,
+	dest string,
+	url string,
+	stage string,
+	progressWeight float64,
+	callback func(stage string, progress float64, message string, currentFile string, speed string, downloaded, total int64),
+) error {
 	tempDest := dest + ".tmp"
 
 	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
@@ -284,12 +352,15 @@ func attemptDownloadInsecure(
 			downloaded += int64(n)
 
 			// Update progress every 100ms
-			if time.Since(lastUpdate) >= 100*time.Millisecond && callback != nil {
+			if time.Since(lastUpdate) >= 100*time.Millisecond {
 				speed := float64(downloaded-lastDownloaded) / time.Since(lastUpdate).Seconds()
-				speedStr := formatSpeed(speed)
-				progress := float64(downloaded) / float64(total) * 100 * progressWeight
+				events.Publish(events.DownloadProgress{URL: url, Downloaded: downloaded, Total: total, SpeedBps: speed})
 
-				callback(stage, progress, "Downloading...", filepath.Base(dest), speedStr, downloaded, total)
+				if callback != nil {
+					speedStr := formatSpeed(speed)
+					progress := float64(downloaded) / float64(total) * 100 * progressWeight
+					callback(stage, progress, "Downloading...", filepath.Base(dest), speedStr, downloaded, total)
+				}
 
 				lastUpdate = time.Now()
 				lastDownloaded = downloaded
@@ -331,38 +402,33 @@ var (
 		DisableCompression:    true,
 	}
 
-	// Transport with insecure TLS for trusted sources (JRE downloads from GitHub/Adoptium)
-	insecureTransport = &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
-		DialContext: (&net.Dialer{
-			Timeout:   30 * time.Second,
-			KeepAlive: 30 * time.Second,
-		}).DialContext,
-		TLSClientConfig: &tls.Config{
-			MinVersion:         tls.VersionTLS12,
-			InsecureSkipVerify: true, // Skip cert verification for systems with broken cert stores
-		},
-		MaxIdleConns:          100,
-		MaxIdleConnsPerHost:   10,
-		IdleConnTimeout:       90 * time.Second,
-		TLSHandshakeTimeout:   10 * time.Second,
-		ResponseHeaderTimeout: 30 * time.Second,
-		DisableCompression:    true,
-	}
-
 	// sharedClient is a singleton HTTP client used to enable TCP connection reuse (Keep-Alive)
 	// across different parts of the application, reducing handshake overhead.
 	sharedClient = &http.Client{
 		Transport: defaultTransport,
 		Timeout:   downloadTimeout,
 	}
+)
+
+// pinnedClientFor returns an HTTP client that validates rawURL's host against the
+// vendored CA bundle instead of the (possibly broken) system cert store, and, when
+// SetPinnedRoots has configured pins for that host, additionally requires the leaf
+// certificate to match one of them. It replaces the previous InsecureSkipVerify
+// fallback for hosts in trustedDomains.
+func pinnedClientFor(rawURL string) *http.Client {
+	host := rawURL
+	if u, err := url.Parse(rawURL); err == nil && u.Hostname() != "" {
+		host = u.Hostname()
+	}
 
-	// insecureClient for trusted sources when cert verification fails
-	insecureClient = &http.Client{
-		Transport: insecureTransport,
+	transport := defaultTransport.Clone()
+	transport.TLSClientConfig = pinnedTLSConfig(host)
+
+	return &http.Client{
+		Transport: transport,
 		Timeout:   downloadTimeout,
 	}
-)
+}
 
 // GetSharedClient returns a globally shared optimized HTTP client
 func GetSharedClient() *http.Client {
@@ -398,11 +464,16 @@ func DownloadLatestReleaseAsset(ctx context.Context, assetName, dest string, cal
 	return DownloadReleaseAsset(ctx, assetName, dest, false, callback)
 }
 
-// DownloadReleaseAsset downloads an asset from either stable release or nightly pre-release
+// DownloadReleaseAsset downloads an asset from either stable release or nightly pre-release.
+// It also fetches a companion "<assetName>.sha256" from the same release and verifies the
+// downloaded bytes against it before the file is kept; if no sidecar is published the asset
+// is downloaded without checksum verification. When a release signing key has been configured
+// via SetReleaseSigningKey, a companion "<assetName>.asc" detached signature is additionally
+// required to verify.
 func DownloadReleaseAsset(ctx context.Context, assetName, dest string, isNightly bool, callback func(stage string, progress float64, message string, currentFile string, speed string, downloaded, total int64)) error {
 	owner := "yyyumeniku"
 	repo := "HyPrism"
-	
+
 	var url string
 	if isNightly {
 		// For nightly builds, get from the latest pre-release (tagged as nightly)
@@ -411,8 +482,25 @@ func DownloadReleaseAsset(ctx context.Context, assetName, dest string, isNightly
 		// For stable releases, get from /releases/latest
 		url = fmt.Sprintf("https://github.com/%s/%s/releases/latest/download/%s", owner, repo, assetName)
 	}
-	
-	return DownloadWithProgress(dest, url, "download", 1.0, callback)
+
+	expectedSha256, err := FetchSha256Sidecar(ctx, url)
+	if err != nil {
+		logging.Logger().Info("no sha256 sidecar, downloading without checksum verification", "asset", assetName, "error", err)
+		return DownloadWithProgress(dest, url, "download", 1.0, callback)
+	}
+
+	if err := DownloadWithVerification(dest, url, expectedSha256, "download", 1.0, callback); err != nil {
+		return err
+	}
+
+	if len(releaseSigningKey) > 0 {
+		if err := verifyReleaseSignature(ctx, url, dest); err != nil {
+			os.Remove(dest)
+			return err
+		}
+	}
+
+	return nil
 }
 
 // GetSystemArch returns the system architecture in a normalized format
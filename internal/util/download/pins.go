@@ -0,0 +1,95 @@
+package download
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	_ "embed"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+//go:generate sh certs/fetch_bundle.sh
+
+//go:embed certs/cacert.pem
+var vendoredCABundle []byte
+
+var (
+	pinnedRootsMu sync.RWMutex
+	pinnedRoots   = map[string][]string{}
+)
+
+// SetPinnedRoots configures SPKI pins (base64-encoded SHA-256 digests of a
+// certificate's SubjectPublicKeyInfo, the same format used by HTTP Public Key
+// Pinning) for trusted domains such as "github.com" or "adoptium.net". The leaf
+// certificate presented by a pinned domain must match one of its pins, in
+// addition to chaining to the vendored CA bundle, or the handshake is rejected.
+func SetPinnedRoots(pins map[string][]string) {
+	pinnedRootsMu.Lock()
+	defer pinnedRootsMu.Unlock()
+
+	pinnedRoots = make(map[string][]string, len(pins))
+	for domain, hashes := range pins {
+		pinnedRoots[strings.ToLower(domain)] = append([]string(nil), hashes...)
+	}
+}
+
+func pinsForDomain(domain string) []string {
+	pinnedRootsMu.RLock()
+	defer pinnedRootsMu.RUnlock()
+	return pinnedRoots[strings.ToLower(domain)]
+}
+
+// vendoredRootPool lazily parses the embedded CA bundle (see certs/fetch_bundle.sh)
+// into an *x509.CertPool, falling back to the system pool when the bundle has not
+// been generated via `go generate` so builds without it still work.
+var vendoredRootPool = sync.OnceValue(func() *x509.CertPool {
+	pool := x509.NewCertPool()
+	if pool.AppendCertsFromPEM(vendoredCABundle) {
+		return pool
+	}
+
+	if sysPool, err := x509.SystemCertPool(); err == nil && sysPool != nil {
+		return sysPool
+	}
+	return x509.NewCertPool()
+})
+
+// pinnedTLSConfig builds a tls.Config that validates the peer certificate chain
+// against the vendored CA bundle, instead of relying on a possibly broken system
+// store, and, when pins are configured for host, additionally requires the leaf
+// certificate's public key to match one of them.
+func pinnedTLSConfig(host string) *tls.Config {
+	pins := pinsForDomain(host)
+
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    vendoredRootPool(),
+	}
+
+	if len(pins) == 0 {
+		return cfg
+	}
+
+	cfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) > 0 {
+			cert, err := x509.ParseCertificate(rawCerts[0])
+			if err != nil {
+				return fmt.Errorf("failed to parse leaf certificate for %s: %w", host, err)
+			}
+
+			sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+			digest := base64.StdEncoding.EncodeToString(sum[:])
+			for _, pin := range pins {
+				if digest == pin {
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("leaf certificate for %s did not match a configured SPKI pin", host)
+	}
+
+	return cfg
+}
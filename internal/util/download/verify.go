@@ -0,0 +1,109 @@
+package download
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+// releaseSigningKey is the optional armored OpenPGP public key used to verify
+// detached signatures published alongside release assets.
+var releaseSigningKey []byte
+
+// SetReleaseSigningKey configures the armored OpenPGP public key used to verify
+// "<asset>.asc" detached signatures fetched alongside GitHub release assets. Pass
+// nil to disable signature verification.
+func SetReleaseSigningKey(armoredKey []byte) {
+	releaseSigningKey = armoredKey
+}
+
+// FetchSha256Sidecar retrieves the expected SHA-256 digest for assetURL by requesting
+// a companion "<assetURL>.sha256" file from the same location. The sidecar may be a
+// bare hex digest or the "sha256sum" style "<digest>  <filename>" format.
+func FetchSha256Sidecar(ctx context.Context, assetURL string) (string, error) {
+	body, err := fetchSidecar(ctx, assetURL+".sha256")
+	if err != nil {
+		return "", err
+	}
+
+	fields := strings.Fields(string(body))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("empty sha256 sidecar for %s", assetURL)
+	}
+
+	digest := strings.ToLower(fields[0])
+	if len(digest) != hex.EncodedLen(sha256.Size) {
+		return "", fmt.Errorf("malformed sha256 sidecar for %s: %q", assetURL, fields[0])
+	}
+
+	return digest, nil
+}
+
+// VerifyDetachedSignature checks an armored OpenPGP detached signature (as published
+// alongside a release, typically "<asset>.asc") against data using pubKeyArmor. It
+// returns nil only if the signature was produced by a key in pubKeyArmor over exactly
+// the bytes in data.
+func VerifyDetachedSignature(data, sigArmor, pubKeyArmor []byte) error {
+	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(pubKeyArmor))
+	if err != nil {
+		return fmt.Errorf("failed to read signing public key: %w", err)
+	}
+
+	block, err := armor.Decode(bytes.NewReader(sigArmor))
+	if err != nil {
+		return fmt.Errorf("failed to decode detached signature: %w", err)
+	}
+
+	if _, err := openpgp.CheckDetachedSignature(keyring, bytes.NewReader(data), block.Body, nil); err != nil {
+		return fmt.Errorf("signature verification failed: %w", err)
+	}
+
+	return nil
+}
+
+// verifyReleaseSignature fetches the "<assetURL>.asc" sidecar and checks it against
+// the file already downloaded to dest using releaseSigningKey.
+func verifyReleaseSignature(ctx context.Context, assetURL, dest string) error {
+	sigArmor, err := fetchSidecar(ctx, assetURL+".asc")
+	if err != nil {
+		return fmt.Errorf("failed to fetch signature for %s: %w", dest, err)
+	}
+
+	data, err := os.ReadFile(dest)
+	if err != nil {
+		return err
+	}
+
+	return VerifyDetachedSignature(data, sigArmor, releaseSigningKey)
+}
+
+// fetchSidecar performs a best-effort GET for a companion file (checksum/signature)
+// published next to a release asset.
+func fetchSidecar(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "HyPrism/1.0")
+
+	resp, err := GetSharedClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d fetching %s", resp.StatusCode, url)
+	}
+
+	return io.ReadAll(resp.Body)
+}
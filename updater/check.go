@@ -1,7 +1,10 @@
 package updater
 
 import (
+	"HyPrism/internal/events"
+	"HyPrism/internal/logging"
 	"HyPrism/internal/util/download"
+	"HyPrism/updater/jre"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -19,11 +22,20 @@ type UpdateInfo struct {
 		Amd64 struct {
 			Launcher Asset `json:"launcher"`
 		} `json:"amd64"`
+		Arm64 struct {
+			Launcher Asset `json:"launcher"`
+		} `json:"arm64"`
+		I386 struct {
+			Launcher Asset `json:"launcher"`
+		} `json:"386"`
 	} `json:"linux"`
 	Windows struct {
 		Amd64 struct {
 			Launcher Asset `json:"launcher"`
 		} `json:"amd64"`
+		Arm64 struct {
+			Launcher Asset `json:"launcher"`
+		} `json:"arm64"`
 	} `json:"windows"`
 	Darwin struct {
 		Amd64 struct {
@@ -51,37 +63,74 @@ func CheckUpdate(ctx context.Context, current string) (*Asset, string, error) {
 	currentClean := strings.TrimPrefix(strings.TrimSpace(current), "v")
 	latestClean := strings.TrimPrefix(strings.TrimSpace(info.Version), "v")
 
-	fmt.Printf("Current version: %s, Latest version: %s\n", current, info.Version)
+	logging.Logger().Debug("checked for update", "current", current, "latest", info.Version)
 
 	if currentClean == latestClean {
-		fmt.Println("Already on latest version")
+		logging.Logger().Debug("already on latest version", "version", current)
 		return nil, "", nil
 	}
 
 	var asset *Asset
 	switch runtime.GOOS {
 	case "windows":
-		asset = &info.Windows.Amd64.Launcher
-		fmt.Printf("Update available for Windows: %s -> %s\n", current, info.Version)
+		if runtime.GOARCH == "arm64" {
+			asset = &info.Windows.Arm64.Launcher
+		} else {
+			asset = &info.Windows.Amd64.Launcher
+		}
 	case "darwin":
 		if runtime.GOARCH == "arm64" {
 			asset = &info.Darwin.Arm64.Launcher
 		} else {
 			asset = &info.Darwin.Amd64.Launcher
 		}
-		fmt.Printf("Update available for macOS: %s -> %s\n", current, info.Version)
 	default:
-		asset = &info.Linux.Amd64.Launcher
-		fmt.Printf("Update available for Linux: %s -> %s\n", current, info.Version)
+		switch runtime.GOARCH {
+		case "arm64":
+			asset = &info.Linux.Arm64.Launcher
+		case "386":
+			asset = &info.Linux.I386.Launcher
+		default:
+			asset = &info.Linux.Amd64.Launcher
+		}
 	}
 
 	if asset.URL == "" {
 		return nil, "", fmt.Errorf("no download URL found for %s/%s", runtime.GOOS, runtime.GOARCH)
 	}
 
+	logging.Logger().Info("update available", "os", runtime.GOOS, "arch", runtime.GOARCH, "current", current, "latest", info.Version)
+	events.Publish(events.UpdateAvailable{CurrentVersion: current, LatestVersion: info.Version})
+
 	return asset, info.Version, nil
 }
 
+// DownloadAsset downloads asset to dest, verifying the download against asset.Sha256
+// when the manifest provided one. Assets published without a digest are downloaded
+// without checksum verification.
+func DownloadAsset(ctx context.Context, asset *Asset, dest string, callback func(stage string, progress float64, message string, currentFile string, speed string, downloaded, total int64)) error {
+	if asset.Sha256 != "" {
+		return download.DownloadWithVerification(dest, asset.URL, asset.Sha256, "download", 1.0, callback)
+	}
+	return download.DownloadWithProgress(dest, asset.URL, "download", 1.0, callback)
+}
+
+// DownloadJRE resolves the Eclipse Temurin JRE for the given Adoptium feature
+// release (e.g. 21) via the jre package, for the current OS/arch, and downloads
+// it to dest, verifying against the digest the Adoptium API published.
+func DownloadJRE(ctx context.Context, feature int, dest string, callback func(stage string, progress float64, message string, currentFile string, speed string, downloaded, total int64)) (jre.JREAsset, error) {
+	asset, err := jre.Resolve(ctx, feature)
+	if err != nil {
+		return jre.JREAsset{}, fmt.Errorf("failed to resolve JRE: %w", err)
+	}
+
+	if err := download.DownloadWithVerification(dest, asset.URL, asset.Sha256, "jre", 1.0, callback); err != nil {
+		return jre.JREAsset{}, err
+	}
+
+	return asset, nil
+}
+
 func fetchUpdateInfo(ctx context.Context) (*UpdateInfo, error) {
 	tmpFile, err := os.CreateTemp("", "version-*.json")
 	if err != nil {
@@ -0,0 +1,154 @@
+// Package jre resolves Eclipse Temurin JRE downloads from the Adoptium API v3
+// for the current platform, so JRE provisioning tracks upstream releases
+// instead of depending on a URL baked into version.json.
+package jre
+
+import (
+	"HyPrism/internal/util/download"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sync"
+	"time"
+)
+
+const (
+	apiBase  = "https://api.adoptium.net/v3"
+	cacheTTL = 6 * time.Hour
+)
+
+// JREAsset describes a single resolved JRE download for a given feature
+// release, OS, and architecture.
+type JREAsset struct {
+	URL         string
+	Sha256      string
+	Size        int64
+	ReleaseName string
+	JavaVersion string
+}
+
+type cacheKey struct {
+	feature int
+	os      string
+	arch    string
+}
+
+type cacheEntry struct {
+	asset     JREAsset
+	fetchedAt time.Time
+}
+
+var (
+	cacheMu sync.Mutex
+	cache   = map[cacheKey]cacheEntry{}
+)
+
+// Resolve returns the JREAsset for the given Adoptium feature release (e.g. 21)
+// matching the current runtime.GOOS/download.GetSystemArch(), consulting an
+// in-memory cache with a 6-hour TTL so first-run UI isn't blocked by repeated
+// API calls.
+func Resolve(ctx context.Context, feature int) (JREAsset, error) {
+	return resolveFor(ctx, feature, adoptiumOS(runtime.GOOS), adoptiumArch(download.GetSystemArch()))
+}
+
+func resolveFor(ctx context.Context, feature int, os, arch string) (JREAsset, error) {
+	key := cacheKey{feature, os, arch}
+
+	cacheMu.Lock()
+	if entry, ok := cache[key]; ok && time.Since(entry.fetchedAt) < cacheTTL {
+		cacheMu.Unlock()
+		return entry.asset, nil
+	}
+	cacheMu.Unlock()
+
+	asset, err := fetchAsset(ctx, feature, os, arch)
+	if err != nil {
+		return JREAsset{}, err
+	}
+
+	cacheMu.Lock()
+	cache[key] = cacheEntry{asset: asset, fetchedAt: time.Now()}
+	cacheMu.Unlock()
+
+	return asset, nil
+}
+
+// apiRelease models the subset of the Adoptium /assets/feature_releases response
+// this package cares about.
+type apiRelease struct {
+	ReleaseName string `json:"release_name"`
+	VersionData struct {
+		Semver string `json:"semver"`
+	} `json:"version_data"`
+	Binaries []struct {
+		Package struct {
+			Link     string `json:"link"`
+			Checksum string `json:"checksum"`
+			Size     int64  `json:"size"`
+		} `json:"package"`
+	} `json:"binaries"`
+}
+
+func fetchAsset(ctx context.Context, feature int, os, arch string) (JREAsset, error) {
+	url := fmt.Sprintf(
+		"%s/assets/feature_releases/%d/ga?architecture=%s&image_type=jre&os=%s&vendor=eclipse",
+		apiBase, feature, arch, os,
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return JREAsset{}, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "HyPrism/1.0")
+
+	resp, err := download.GetSharedClient().Do(req)
+	if err != nil {
+		return JREAsset{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return JREAsset{}, fmt.Errorf("adoptium API returned status %d for %s/%s", resp.StatusCode, os, arch)
+	}
+
+	var releases []apiRelease
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return JREAsset{}, fmt.Errorf("failed to decode adoptium response: %w", err)
+	}
+	if len(releases) == 0 || len(releases[0].Binaries) == 0 {
+		return JREAsset{}, fmt.Errorf("no JRE found for feature %d on %s/%s", feature, os, arch)
+	}
+
+	pkg := releases[0].Binaries[0].Package
+	return JREAsset{
+		URL:         pkg.Link,
+		Sha256:      pkg.Checksum,
+		Size:        pkg.Size,
+		ReleaseName: releases[0].ReleaseName,
+		JavaVersion: releases[0].VersionData.Semver,
+	}, nil
+}
+
+// adoptiumOS maps a Go runtime.GOOS to the value the Adoptium API expects.
+func adoptiumOS(goos string) string {
+	if goos == "darwin" {
+		return "mac"
+	}
+	return goos
+}
+
+// adoptiumArch maps download.GetSystemArch()'s normalized architecture to the
+// value the Adoptium API expects.
+func adoptiumArch(arch string) string {
+	switch arch {
+	case "arm64":
+		return "aarch64"
+	case "386":
+		return "x86"
+	default:
+		return arch
+	}
+}